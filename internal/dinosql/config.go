@@ -1,12 +1,19 @@
 package dinosql
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
 
 	"github.com/kyleconroy/sqlc/internal/pg"
 )
@@ -27,44 +34,72 @@ The only supported version is "1".
 const errMessageNoPackages = `No packages are configured`
 
 type GenerateSettings struct {
-	Version    string            `json:"version"`
-	Packages   []PackageSettings `json:"packages"`
-	Overrides  []Override        `json:"overrides,omitempty"`
-	Rename     map[string]string `json:"rename,omitempty"`
+	Version    string            `json:"version" yaml:"version"`
+	Packages   []PackageSettings `json:"packages" yaml:"packages"`
+	Overrides  []Override        `json:"overrides,omitempty" yaml:"overrides,omitempty"`
+	Rename     map[string]string `json:"rename,omitempty" yaml:"rename,omitempty"`
+	Vars       []Var             `json:"vars,omitempty" yaml:"vars,omitempty"`
+	Plugins    []PluginSettings  `json:"plugins,omitempty" yaml:"plugins,omitempty"`
 	PackageMap map[string]PackageSettings
 }
 
-type Engine string
+// Var declares a named value that can be substituted into string fields of
+// the config via Go templates, e.g. `"queries": "{{.QueryRoot}}/users"`.
+// Values are resolved from a CLI `--var name=value` flag, then the
+// `SQLC_VAR_<NAME>` environment variable, then Default.
+type Var struct {
+	Name     string      `json:"name" yaml:"name"`
+	Required bool        `json:"required,omitempty" yaml:"required,omitempty"`
+	Default  interface{} `json:"default,omitempty" yaml:"default,omitempty"`
+}
 
 const (
-	EngineMySQL      Engine = "mysql"
-	EnginePostgreSQL Engine = "postgresql"
+	EngineMySQL      = "mysql"
+	EnginePostgreSQL = "postgresql"
 )
 
+// DefaultDriver is the driver assumed for a package that doesn't set one,
+// matching the stdlib `database/sql` interfaces sqlc has always targeted.
+const DefaultDriver = "database/sql"
+
 type PackageSettings struct {
-	Name                string     `json:"name"`
-	Engine              Engine     `json:"engine,omitempty"`
-	Path                string     `json:"path"`
-	Schema              string     `json:"schema"`
-	Queries             string     `json:"queries"`
-	EmitInterface       bool       `json:"emit_interface"`
-	EmitJSONTags        bool       `json:"emit_json_tags"`
-	EmitPreparedQueries bool       `json:"emit_prepared_queries"`
-	Overrides           []Override `json:"overrides"`
+	Name    string `json:"name" yaml:"name"`
+	Engine  string `json:"engine,omitempty" yaml:"engine,omitempty"`
+	Path    string `json:"path" yaml:"path"`
+	Schema  string `json:"schema" yaml:"schema"`
+	Queries string `json:"queries" yaml:"queries"`
+	// Driver is the Go SQL driver the generated code targets, e.g.
+	// `database/sql`, `pgx/v4`, or `pgx/v5`. It selects which Overrides
+	// apply; see ResolveOverrides.
+	Driver              string           `json:"driver,omitempty" yaml:"driver,omitempty"`
+	EmitInterface       bool             `json:"emit_interface" yaml:"emit_interface"`
+	EmitJSONTags        bool             `json:"emit_json_tags" yaml:"emit_json_tags"`
+	EmitPreparedQueries bool             `json:"emit_prepared_queries" yaml:"emit_prepared_queries"`
+	Overrides           []Override       `json:"overrides" yaml:"overrides"`
+	Vars                []Var            `json:"vars,omitempty" yaml:"vars,omitempty"`
+	Plugins             []PluginSettings `json:"plugins,omitempty" yaml:"plugins,omitempty"`
 }
 
 type Override struct {
 	// name of the golang type to use, e.g. `github.com/segmentio/ksuid.KSUID`
-	GoType string `json:"go_type"`
+	GoType string `json:"go_type" yaml:"go_type"`
 
 	// fully qualified name of the Go type, e.g. `github.com/segmentio/ksuid.KSUID`
-	PostgresType string `json:"postgres_type"`
+	PostgresType string `json:"postgres_type" yaml:"postgres_type"`
 
 	// True if the GoType should override if the maching postgres type is nullable
-	Null bool `json:"null"`
+	Null bool `json:"null" yaml:"null"`
 
 	// fully qualified name of the column, e.g. `accounts.id`
-	Column string `json:"column"`
+	Column string `json:"column" yaml:"column"`
+
+	// Drivers restricts this override to the given Go SQL drivers, e.g.
+	// `["pgx/v4", "pgx/v5"]`. An empty list matches any driver.
+	Drivers []string `json:"drivers,omitempty" yaml:"drivers,omitempty"`
+
+	// Dialect restricts this override to a specific SQL dialect, e.g.
+	// `postgresql`. Empty matches any dialect.
+	Dialect string `json:"dialect,omitempty" yaml:"dialect,omitempty"`
 
 	columnName string
 	table      pg.FQN
@@ -99,6 +134,25 @@ func (o *Override) Parse() error {
 		}
 	}
 
+	// validate Drivers
+	seenDrivers := map[string]bool{}
+	for _, d := range o.Drivers {
+		if d == "" || strings.TrimSpace(d) != d {
+			return fmt.Errorf("Override driver %q is not valid", d)
+		}
+		if seenDrivers[d] {
+			return fmt.Errorf("Override driver %q is listed more than once", d)
+		}
+		seenDrivers[d] = true
+	}
+
+	// validate Dialect
+	if o.Dialect != "" {
+		if _, err := LookupEngine(o.Dialect); err != nil {
+			return fmt.Errorf("Override dialect %q does not name a registered engine", o.Dialect)
+		}
+	}
+
 	// validate GoType
 	lastDot := strings.LastIndex(o.GoType, ".")
 	if lastDot == -1 {
@@ -119,6 +173,132 @@ func (o *Override) Parse() error {
 	return nil
 }
 
+// ResolveOpts selects which Overrides apply when generating a package.
+type ResolveOpts struct {
+	Driver  string
+	Dialect string
+
+	// GlobalOverrides are the Overrides declared at the top level of the
+	// config file, considered after any package-specific ones.
+	GlobalOverrides []Override
+}
+
+// ResolveOverrides returns the Overrides that apply to pkg given opts, in
+// priority order: package overrides before global overrides, and within
+// each of those, overrides naming opts.Driver explicitly before overrides
+// that apply to every driver.
+//
+// The code generator is expected to call this once per package, passing
+// PackageSettings.Driver/Dialect as opts, instead of iterating
+// PackageSettings.Overrides directly. That generator package isn't part
+// of this tree yet, so there is no call site here to wire up; this is the
+// seam the generator should use once it lands.
+func ResolveOverrides(pkg PackageSettings, opts ResolveOpts) []Override {
+	pkgOverrides := matchingOverrides(pkg.Overrides, opts)
+	globalOverrides := matchingOverrides(opts.GlobalOverrides, opts)
+	return append(pkgOverrides, globalOverrides...)
+}
+
+func matchingOverrides(overrides []Override, opts ResolveOpts) []Override {
+	var matched []Override
+	for _, o := range overrides {
+		if o.matches(opts) {
+			matched = append(matched, o)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return len(matched[i].Drivers) > 0 && len(matched[j].Drivers) == 0
+	})
+	return matched
+}
+
+func (o Override) matches(opts ResolveOpts) bool {
+	if len(o.Drivers) > 0 {
+		driverMatch := false
+		for _, d := range o.Drivers {
+			if d == opts.Driver {
+				driverMatch = true
+				break
+			}
+		}
+		if !driverMatch {
+			return false
+		}
+	}
+	if o.Dialect != "" && o.Dialect != opts.Dialect {
+		return false
+	}
+	return true
+}
+
+// resolveVars resolves a value for each declared Var, preferring cliVars,
+// then the SQLC_VAR_<NAME> environment variable, then the Var's Default.
+// It returns an error if a Required Var has no value from any source.
+func resolveVars(vars []Var, cliVars map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(vars))
+	for _, v := range vars {
+		if val, ok := cliVars[v.Name]; ok {
+			resolved[v.Name] = val
+			continue
+		}
+		if val, ok := os.LookupEnv("SQLC_VAR_" + strings.ToUpper(v.Name)); ok {
+			resolved[v.Name] = val
+			continue
+		}
+		if v.Default != nil {
+			resolved[v.Name] = formatVarDefault(v.Default)
+			continue
+		}
+		if v.Required {
+			return nil, fmt.Errorf("var %q is required but was not set", v.Name)
+		}
+	}
+	return resolved, nil
+}
+
+// substituteVars runs s through text/template with vars, leaving s
+// untouched if it contains no template action. Referencing a var that
+// isn't in vars is an error rather than silently rendering "<no value>".
+func substituteVars(s string, vars map[string]string) (string, error) {
+	if !strings.Contains(s, "{{") {
+		return s, nil
+	}
+	tmpl, err := template.New("sqlc-var").Option("missingkey=error").Parse(s)
+	if err != nil {
+		return "", fmt.Errorf("parsing template %q: %w", s, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("executing template %q: %w", s, err)
+	}
+	return buf.String(), nil
+}
+
+// formatVarDefault stringifies a Var's Default for template substitution.
+// Defaults decoded from JSON numbers (and YAML floats) arrive as float64; formatting
+// those with %v can print round numbers in scientific notation (e.g.
+// 100000000 as "1e+08"), so numeric defaults are formatted with the 'f'
+// verb instead, which never uses scientific notation.
+func formatVarDefault(v interface{}) string {
+	switch t := v.(type) {
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
+
+func mergeVars(a, b map[string]string) map[string]string {
+	merged := make(map[string]string, len(a)+len(b))
+	for k, v := range a {
+		merged[k] = v
+	}
+	for k, v := range b {
+		merged[k] = v
+	}
+	return merged
+}
+
 var ErrMissingVersion = errors.New("no version number")
 var ErrUnknownVersion = errors.New("invalid version number")
 var ErrNoPackages = errors.New("no packages")
@@ -126,12 +306,77 @@ var ErrNoPackageName = errors.New("missing package name")
 var ErrNoPackagePath = errors.New("missing package path")
 
 func ParseConfig(rd io.Reader) (GenerateSettings, error) {
+	return ParseConfigWithVars(rd, nil)
+}
+
+// ParseConfigWithVars is like ParseConfig, but resolves any top-level or
+// per-package Vars using cliVars (as set via `--var name=value`) before
+// falling back to the environment and each Var's Default, then
+// substitutes them into Path, Schema, Queries, Name, and Override
+// GoType/Column using Go templates.
+func ParseConfigWithVars(rd io.Reader, cliVars map[string]string) (GenerateSettings, error) {
 	dec := json.NewDecoder(rd)
 	dec.DisallowUnknownFields()
 	var config GenerateSettings
 	if err := dec.Decode(&config); err != nil {
 		return config, err
 	}
+	return validateConfig(config, cliVars)
+}
+
+// ParseConfigYAML is the YAML equivalent of ParseConfig: it reads a
+// sqlc.yaml/sqlc.yml document instead of sqlc.json, applying the same
+// unknown-field strictness and Vars resolution.
+func ParseConfigYAML(rd io.Reader) (GenerateSettings, error) {
+	return ParseConfigYAMLWithVars(rd, nil)
+}
+
+// ParseConfigYAMLWithVars is the YAML equivalent of ParseConfigWithVars.
+func ParseConfigYAMLWithVars(rd io.Reader, cliVars map[string]string) (GenerateSettings, error) {
+	dec := yaml.NewDecoder(rd)
+	dec.KnownFields(true)
+	var config GenerateSettings
+	if err := dec.Decode(&config); err != nil {
+		return config, err
+	}
+	return validateConfig(config, cliVars)
+}
+
+// LoadConfig reads and parses the sqlc config at path, picking JSON or
+// YAML based on its extension (`.json`, `.yaml`, `.yml`). Passing "-"
+// reads from stdin, trying JSON first and falling back to YAML, since
+// stdin has no extension to dispatch on.
+func LoadConfig(path string) (GenerateSettings, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return GenerateSettings{}, err
+		}
+		config, jsonErr := ParseConfig(bytes.NewReader(data))
+		if jsonErr == nil {
+			return config, nil
+		}
+		config, yamlErr := ParseConfigYAML(bytes.NewReader(data))
+		if yamlErr == nil {
+			return config, nil
+		}
+		return config, fmt.Errorf("stdin is neither valid JSON (%s) nor valid YAML (%s)", jsonErr, yamlErr)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return GenerateSettings{}, err
+	}
+	defer f.Close()
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		return ParseConfigYAML(f)
+	default:
+		return ParseConfig(f)
+	}
+}
+
+func validateConfig(config GenerateSettings, cliVars map[string]string) (GenerateSettings, error) {
 	if config.Version == "" {
 		return config, ErrMissingVersion
 	}
@@ -141,32 +386,86 @@ func ParseConfig(rd io.Reader) (GenerateSettings, error) {
 	if len(config.Packages) == 0 {
 		return config, ErrNoPackages
 	}
+	globalVars, err := resolveVars(config.Vars, cliVars)
+	if err != nil {
+		return config, err
+	}
+	for i := range config.Plugins {
+		if err := config.Plugins[i].Parse(); err != nil {
+			return config, err
+		}
+	}
 	for i := range config.Overrides {
+		if err := substituteOverrideVars(&config.Overrides[i], globalVars); err != nil {
+			return config, err
+		}
 		if err := config.Overrides[i].Parse(); err != nil {
 			return config, err
 		}
 	}
 	for j := range config.Packages {
+		pkgVars, err := resolveVars(config.Packages[j].Vars, cliVars)
+		if err != nil {
+			return config, err
+		}
+		vars := mergeVars(globalVars, pkgVars)
+		if config.Packages[j].Path, err = substituteVars(config.Packages[j].Path, vars); err != nil {
+			return config, err
+		}
 		if config.Packages[j].Path == "" {
 			return config, ErrNoPackagePath
 		}
+		if config.Packages[j].Schema, err = substituteVars(config.Packages[j].Schema, vars); err != nil {
+			return config, err
+		}
+		if config.Packages[j].Queries, err = substituteVars(config.Packages[j].Queries, vars); err != nil {
+			return config, err
+		}
+		if config.Packages[j].Name, err = substituteVars(config.Packages[j].Name, vars); err != nil {
+			return config, err
+		}
 		for i := range config.Packages[j].Overrides {
+			if err := substituteOverrideVars(&config.Packages[j].Overrides[i], vars); err != nil {
+				return config, err
+			}
 			if err := config.Packages[j].Overrides[i].Parse(); err != nil {
 				return config, err
 			}
 		}
+		for i := range config.Packages[j].Plugins {
+			if err := config.Packages[j].Plugins[i].Parse(); err != nil {
+				return config, err
+			}
+		}
 		if config.Packages[j].Name == "" {
 			config.Packages[j].Name = filepath.Base(config.Packages[j].Path)
 		}
 		if config.Packages[j].Engine == "" {
 			config.Packages[j].Engine = EnginePostgreSQL
 		}
+		if config.Packages[j].Driver == "" {
+			config.Packages[j].Driver = DefaultDriver
+		}
+		if _, err := LookupEngine(config.Packages[j].Engine); err != nil {
+			return config, err
+		}
 	}
-	err := config.PopulatePkgMap()
+	err = config.PopulatePkgMap()
 
 	return config, err
 }
 
+func substituteOverrideVars(o *Override, vars map[string]string) error {
+	var err error
+	if o.GoType, err = substituteVars(o.GoType, vars); err != nil {
+		return err
+	}
+	if o.Column, err = substituteVars(o.Column, vars); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (s *GenerateSettings) PopulatePkgMap() error {
 	packageMap := make(map[string]PackageSettings)
 
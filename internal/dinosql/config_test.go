@@ -0,0 +1,226 @@
+package dinosql
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveOverrides(t *testing.T) {
+	pgxV4 := Override{Drivers: []string{"pgx/v4"}, Dialect: "", GoType: "a/b.T"}
+	pgxV5 := Override{Drivers: []string{"pgx/v5"}, GoType: "a/b.T"}
+	wildcard := Override{GoType: "a/b.T"}
+	global := Override{Drivers: []string{"pgx/v4"}, GoType: "a/b.Global"}
+
+	pkg := PackageSettings{Overrides: []Override{wildcard, pgxV4, pgxV5}}
+	opts := ResolveOpts{
+		Driver:          "pgx/v4",
+		GlobalOverrides: []Override{global},
+	}
+
+	got := ResolveOverrides(pkg, opts)
+	want := []Override{pgxV4, wildcard, global}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ResolveOverrides() = %+v, want %+v", got, want)
+	}
+}
+
+func TestResolveOverridesDialect(t *testing.T) {
+	mysqlOnly := Override{Dialect: EngineMySQL, GoType: "a/b.T"}
+	pkg := PackageSettings{Overrides: []Override{mysqlOnly}}
+
+	got := ResolveOverrides(pkg, ResolveOpts{Driver: DefaultDriver, Dialect: EnginePostgreSQL})
+	if len(got) != 0 {
+		t.Fatalf("expected mysql-only override to be excluded for postgresql dialect, got %+v", got)
+	}
+
+	got = ResolveOverrides(pkg, ResolveOpts{Driver: DefaultDriver, Dialect: EngineMySQL})
+	if len(got) != 1 {
+		t.Fatalf("expected mysql-only override to match mysql dialect, got %+v", got)
+	}
+}
+
+func TestMatchingOverridesSpecificDriverFirst(t *testing.T) {
+	wildcard := Override{GoType: "a/b.T"}
+	specific := Override{Drivers: []string{"pgx/v5"}, GoType: "a/b.T"}
+
+	got := matchingOverrides([]Override{wildcard, specific}, ResolveOpts{Driver: "pgx/v5"})
+	if len(got) != 2 || got[0].Drivers[0] != "pgx/v5" {
+		t.Fatalf("expected driver-specific override first, got %+v", got)
+	}
+}
+
+func TestResolveVars(t *testing.T) {
+	t.Run("cli overrides env and default", func(t *testing.T) {
+		os.Setenv("SQLC_VAR_ROOT", "from-env")
+		defer os.Unsetenv("SQLC_VAR_ROOT")
+
+		vars := []Var{{Name: "root", Default: "from-default"}}
+		got, err := resolveVars(vars, map[string]string{"root": "from-cli"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got["root"] != "from-cli" {
+			t.Fatalf("got %q, want %q", got["root"], "from-cli")
+		}
+	})
+
+	t.Run("env overrides default", func(t *testing.T) {
+		os.Setenv("SQLC_VAR_ROOT", "from-env")
+		defer os.Unsetenv("SQLC_VAR_ROOT")
+
+		vars := []Var{{Name: "root", Default: "from-default"}}
+		got, err := resolveVars(vars, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got["root"] != "from-env" {
+			t.Fatalf("got %q, want %q", got["root"], "from-env")
+		}
+	})
+
+	t.Run("required var missing everywhere errors", func(t *testing.T) {
+		vars := []Var{{Name: "root", Required: true}}
+		if _, err := resolveVars(vars, nil); err == nil {
+			t.Fatal("expected error for missing required var")
+		}
+	})
+
+	t.Run("whole-number float default is not scientific notation", func(t *testing.T) {
+		vars := []Var{{Name: "n", Default: float64(100000000)}}
+		got, err := resolveVars(vars, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got["n"] != "100000000" {
+			t.Fatalf("got %q, want %q", got["n"], "100000000")
+		}
+	})
+
+	t.Run("fractional float default keeps its decimal", func(t *testing.T) {
+		vars := []Var{{Name: "n", Default: float64(1.5)}}
+		got, err := resolveVars(vars, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got["n"] != "1.5" {
+			t.Fatalf("got %q, want %q", got["n"], "1.5")
+		}
+	})
+
+	t.Run("whole-number float default beyond int64 range does not overflow", func(t *testing.T) {
+		vars := []Var{{Name: "n", Default: float64(1e20)}}
+		got, err := resolveVars(vars, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got["n"] != "100000000000000000000" {
+			t.Fatalf("got %q, want %q", got["n"], "100000000000000000000")
+		}
+	})
+}
+
+func TestSubstituteVars(t *testing.T) {
+	t.Run("no template action is a no-op", func(t *testing.T) {
+		got, err := substituteVars("queries", map[string]string{})
+		if err != nil || got != "queries" {
+			t.Fatalf("got (%q, %v), want (%q, nil)", got, err, "queries")
+		}
+	})
+
+	t.Run("known var substitutes", func(t *testing.T) {
+		got, err := substituteVars("{{.Root}}/users", map[string]string{"Root": "queries"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != "queries/users" {
+			t.Fatalf("got %q, want %q", got, "queries/users")
+		}
+	})
+
+	t.Run("unknown var errors instead of rendering <no value>", func(t *testing.T) {
+		_, err := substituteVars("{{.Typo}}/users", map[string]string{"Root": "queries"})
+		if err == nil {
+			t.Fatal("expected error for unresolved var, got nil")
+		}
+	})
+}
+
+func TestParseConfigYAML(t *testing.T) {
+	doc := `
+version: "1"
+packages:
+  - path: gen
+    name: querytest
+    schema: schema.sql
+    queries: query.sql
+    engine: postgresql
+`
+	config, err := ParseConfigYAML(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Packages) != 1 {
+		t.Fatalf("got %d packages, want 1", len(config.Packages))
+	}
+	pkg := config.Packages[0]
+	if pkg.Name != "querytest" || pkg.Path != "gen" || pkg.Driver != DefaultDriver {
+		t.Fatalf("unexpected package settings: %+v", pkg)
+	}
+}
+
+func TestParseConfigUnknownEngine(t *testing.T) {
+	doc := `{
+		"version": "1",
+		"packages": [{"path": "gen", "engine": "sqlite"}]
+	}`
+	if _, err := ParseConfig(strings.NewReader(doc)); err == nil {
+		t.Fatal("expected error for an engine with no registered implementation, got nil")
+	}
+}
+
+func TestPluginSettingsParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		plugin  PluginSettings
+		wantErr bool
+	}{
+		{"valid", PluginSettings{Name: "ts", Path: "./ts-plugin"}, false},
+		{"missing name", PluginSettings{Path: "./ts-plugin"}, true},
+		{"missing path", PluginSettings{Name: "ts"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.plugin.Parse()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseConfigPluginMissingPath(t *testing.T) {
+	doc := `{
+		"version": "1",
+		"packages": [{
+			"path": "gen",
+			"plugins": [{"name": "ts"}]
+		}]
+	}`
+	if _, err := ParseConfig(strings.NewReader(doc)); err == nil {
+		t.Fatal("expected error for a plugin missing `path`, got nil")
+	}
+}
+
+func TestParseConfigYAMLUnknownField(t *testing.T) {
+	doc := `
+version: "1"
+packages:
+  - path: gen
+    bogus_field: true
+`
+	if _, err := ParseConfigYAML(strings.NewReader(doc)); err == nil {
+		t.Fatal("expected error for unknown field, got nil")
+	}
+}
@@ -0,0 +1,56 @@
+package dinosql
+
+import (
+	"fmt"
+
+	"github.com/kyleconroy/sqlc/internal/pg"
+)
+
+// Parser turns schema source for a particular database engine into a
+// pg.Catalog that the generator can walk.
+type Parser interface {
+	Parse(schema string) (*pg.Catalog, error)
+}
+
+// Engine describes everything the generator needs to know about a
+// particular database backend: how to parse its SQL dialect, what its
+// built-in catalog looks like, and how its types map onto Go types.
+// Implementations register themselves with RegisterEngine, usually from
+// an init() function, so that third parties can add support for engines
+// sqlc doesn't ship with.
+type Engine interface {
+	// Name is the string used in the `engine` field of sqlc.json, e.g.
+	// "mysql" or "postgresql".
+	Name() string
+
+	// Parser returns the SQL parser used to build a pg.Catalog for this
+	// engine.
+	Parser() Parser
+
+	// Catalog returns the built-in catalog (schemas, types, functions)
+	// that ships with this engine.
+	Catalog() *pg.Catalog
+
+	// GoDataType maps a database type name to the Go type used to
+	// represent it in generated code.
+	GoDataType(sqlType string, notNull bool) (string, error)
+}
+
+var engines = map[string]Engine{}
+
+// RegisterEngine makes an Engine available under the given name. It is
+// intended to be called from the init() function of an Engine
+// implementation, including by packages outside of sqlc.
+func RegisterEngine(name string, e Engine) {
+	engines[name] = e
+}
+
+// LookupEngine returns the Engine registered under name, or an error if
+// no engine has been registered with that name.
+func LookupEngine(name string) (Engine, error) {
+	e, ok := engines[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown engine %q", name)
+	}
+	return e, nil
+}
@@ -0,0 +1,44 @@
+package dinosql
+
+import (
+	"fmt"
+
+	"github.com/kyleconroy/sqlc/internal/pg"
+)
+
+func init() {
+	RegisterEngine(EngineMySQL, mysqlEngine{})
+}
+
+type mysqlEngine struct{}
+
+func (mysqlEngine) Name() string { return EngineMySQL }
+
+func (mysqlEngine) Parser() Parser { return mysqlParser{} }
+
+func (mysqlEngine) Catalog() *pg.Catalog {
+	return &pg.Catalog{}
+}
+
+func (mysqlEngine) GoDataType(sqlType string, notNull bool) (string, error) {
+	switch sqlType {
+	case "varchar", "text":
+		if notNull {
+			return "string", nil
+		}
+		return "sql.NullString", nil
+	case "int", "bigint":
+		if notNull {
+			return "int64", nil
+		}
+		return "sql.NullInt64", nil
+	default:
+		return "", fmt.Errorf("unsupported mysql type: %s", sqlType)
+	}
+}
+
+type mysqlParser struct{}
+
+func (mysqlParser) Parse(schema string) (*pg.Catalog, error) {
+	return nil, fmt.Errorf("mysql: schema parsing is not yet implemented")
+}
@@ -0,0 +1,61 @@
+package dinosql
+
+import (
+	"fmt"
+
+	"github.com/kyleconroy/sqlc/internal/pg"
+)
+
+func init() {
+	RegisterEngine(EnginePostgreSQL, postgresqlEngine{})
+}
+
+type postgresqlEngine struct{}
+
+func (postgresqlEngine) Name() string { return EnginePostgreSQL }
+
+func (postgresqlEngine) Parser() Parser { return postgresqlParser{} }
+
+func (postgresqlEngine) Catalog() *pg.Catalog {
+	return &pg.Catalog{}
+}
+
+func (postgresqlEngine) GoDataType(sqlType string, notNull bool) (string, error) {
+	switch sqlType {
+	case "uuid":
+		return "uuid.UUID", nil
+	case "bool":
+		if notNull {
+			return "bool", nil
+		}
+		return "sql.NullBool", nil
+	case "text", "varchar":
+		if notNull {
+			return "string", nil
+		}
+		return "sql.NullString", nil
+	case "int", "int4", "int8":
+		if notNull {
+			return "int64", nil
+		}
+		return "sql.NullInt64", nil
+	case "float4", "float8":
+		if notNull {
+			return "float64", nil
+		}
+		return "sql.NullFloat64", nil
+	case "timestamp", "timestamptz":
+		if notNull {
+			return "time.Time", nil
+		}
+		return "sql.NullTime", nil
+	default:
+		return "", fmt.Errorf("unsupported postgresql type: %s", sqlType)
+	}
+}
+
+type postgresqlParser struct{}
+
+func (postgresqlParser) Parse(schema string) (*pg.Catalog, error) {
+	return nil, fmt.Errorf("postgresql.Parse: not implemented")
+}
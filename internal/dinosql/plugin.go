@@ -0,0 +1,75 @@
+package dinosql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kyleconroy/sqlc/internal/pg"
+)
+
+// PluginSettings references a user-authored plugin that can mutate the
+// catalog or queries sqlc has parsed, or emit additional generated files
+// alongside sqlc's own output.
+type PluginSettings struct {
+	Name string `json:"name" yaml:"name"`
+
+	// Path is either a Go plugin `.so` to load in-process, or the path to
+	// an external binary invoked with a GenerateRequest as JSON on stdin
+	// and expected to write a []File as JSON to stdout.
+	Path string `json:"path" yaml:"path"`
+
+	// Options is passed through to the plugin unparsed; its shape is
+	// defined by the plugin itself.
+	Options json.RawMessage `json:"options,omitempty" yaml:"options,omitempty"`
+}
+
+func (p *PluginSettings) Parse() error {
+	if p.Name == "" {
+		return fmt.Errorf("plugin is missing a `name`")
+	}
+	if p.Path == "" {
+		return fmt.Errorf("plugin %q is missing a `path`", p.Name)
+	}
+	return nil
+}
+
+// Query is a single parsed query that the generator turns into a Go
+// method. It's exposed to plugins so they can inspect or emit code based
+// on the same information sqlc's own generator uses.
+type Query struct {
+	Name string
+	SQL  string
+}
+
+// File is a single file emitted by a Plugin, to be written out alongside
+// sqlc's own generated output.
+type File struct {
+	Name     string
+	Contents []byte
+}
+
+// GenerateRequest carries everything sqlc knows about a package at the
+// point plugins run.
+type GenerateRequest struct {
+	Package PackageSettings
+	Catalog *pg.Catalog
+	Queries []Query
+}
+
+// Plugin lets users hook into code generation without forking sqlc.
+// Plugins run in the order they're declared, after sqlc finishes parsing
+// but before it renders Go code: MutateCatalog and MutateQueries can
+// rewrite what the generator sees, and EmitFiles can produce additional
+// output files (e.g. a TypeScript client or an OpenAPI spec).
+//
+// Loading and invoking Plugins (resolving PluginSettings.Path to either a
+// Go plugin or an external binary, then running MutateCatalog /
+// MutateQueries / EmitFiles in declared order) is the generator's job.
+// That generator package isn't part of this tree yet, so there's no call
+// site here to wire up; ParseConfig only validates the PluginSettings
+// references.
+type Plugin interface {
+	MutateCatalog(*pg.Catalog) error
+	MutateQueries([]Query) error
+	EmitFiles(GenerateRequest) ([]File, error)
+}